@@ -0,0 +1,102 @@
+package log
+
+import (
+	"context"
+	"sync"
+)
+
+//asyncRecord - One queued write: which level bucket's stdlog.Logger should
+//print it, and the already-formatted line
+type asyncRecord struct {
+	lvl  int32
+	line string
+}
+
+//asyncSink - Drains buffered log lines on a dedicated goroutine so callers
+//on the hot path never block on the underlying stdlog.Logger write.
+//closing is closed (once, via closeOnce) to signal Close was called; ch
+//itself is never closed, so a concurrent output() can never panic sending
+//on it.
+type asyncSink struct {
+	ch        chan asyncRecord
+	closing   chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+//newAsyncSink - Starts the draining goroutine; stopped via Logger.Close
+func newAsyncSink(out *Logger, bufSize int) *asyncSink {
+	a := &asyncSink{
+		ch:      make(chan asyncRecord, bufSize),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(a.done)
+		for {
+			select {
+			case r := <-a.ch:
+				out.logs[r.lvl].Print(r.line)
+			case <-a.closing:
+				a.drain(out)
+				return
+			}
+		}
+	}()
+	return a
+}
+
+//drain - Flushes whatever is already buffered in ch once closing fires
+func (a *asyncSink) drain(out *Logger) {
+	for {
+		select {
+		case r := <-a.ch:
+			out.logs[r.lvl].Print(r.line)
+		default:
+			return
+		}
+	}
+}
+
+//NewAsync - Creates a Logger like New, but routes every write through a
+//buffered channel drained by a background goroutine, instead of writing
+//synchronously on the calling goroutine. Use Close to flush pending records.
+func NewAsync(c Cfg, bufSize int) *Logger {
+	logger := New(c)
+	logger.async = newAsyncSink(logger, bufSize)
+	return logger
+}
+
+//Close - Flushes pending records written via NewAsync and stops its
+//background goroutine, waiting for the drain to finish or ctx to expire.
+//Close on a synchronous Logger (created via New) is a no-op. Safe to call
+//more than once; any output() call after Close drops its record instead of
+//writing it, and one concurrent with Close may either write or drop it.
+func (l *Logger) Close(ctx context.Context) error {
+	if l.async == nil {
+		return nil
+	}
+	l.async.closeOnce.Do(func() { close(l.async.closing) })
+	select {
+	case <-l.async.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//output - Writes a fully-formatted line for level n, either synchronously
+//or, for a Logger created via NewAsync, by handing it to the background
+//goroutine. A record made after Close is dropped rather than risking a send
+//on a channel no one is draining anymore; one raced against Close may be
+//written or dropped.
+func (l *Logger) output(n int32, line string) {
+	if l.async != nil {
+		select {
+		case l.async.ch <- asyncRecord{lvl: n, line: line}:
+		case <-l.async.closing:
+		}
+		return
+	}
+	l.logs[n].Print(line)
+}
@@ -0,0 +1,83 @@
+package log
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//TestNewAsyncCloseFlushes - records written via NewAsync must have reached
+//the Sink by the time Close returns.
+func TestNewAsyncCloseFlushes(t *testing.T) {
+	f, err := ioutil.TempFile("", "log-async-*.log")
+	if err != nil {
+		t.Fatalf("TempFile: %v", err)
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	l := NewAsync(Cfg{
+		Level:  LevelDebug,
+		Prefix: "t",
+		Outputs: []OutputCfg{
+			{Path: path, Level: LevelDebug},
+		},
+	}, 16)
+
+	l.Info("t")("hello %d", 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := l.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(got), "hello 1") {
+		t.Fatalf("file content = %q, want it to contain %q", got, "hello 1")
+	}
+}
+
+//TestNewAsyncCloseConcurrentWithOutput - output() racing Close() may write
+//or drop the record, but must not panic sending on a closed channel.
+func TestNewAsyncCloseConcurrentWithOutput(t *testing.T) {
+	f, err := ioutil.TempFile("", "log-async-race-*.log")
+	if err != nil {
+		t.Fatalf("TempFile: %v", err)
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	l := NewAsync(Cfg{
+		Level:  LevelDebug,
+		Prefix: "t",
+		Outputs: []OutputCfg{
+			{Path: path, Level: LevelDebug},
+		},
+	}, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l.Info("t")("spam %d", i)
+		}(i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := l.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	wg.Wait()
+}
@@ -1,6 +1,16 @@
+//Package log (log1) is the earlier int-level design.
+//
+//SCOPE NOTE: chunk0-6 asked to unify this package and the root log package
+//behind one API. That unification was not done here; this package only
+//received the printf/level-gating bug fixes from that request. It still
+//ships its own divergent int-level Logger, duplicated from the root
+//string-level one. Unifying them (e.g. making this package a thin
+//compatibility shim over the root Logger) is left as follow-up work.
+//New code should use the root log package, not this one.
 package log
 
 import (
+	"fmt"
 	"io"
 	stdlog "log"
 	"strings"
@@ -76,7 +86,7 @@ func (l *Logger) Error(ctx ...string) PrintFunc {
 //Pass Contexts along with actual info messages
 //Verifies if Info is allowed, if so adds Info String
 func (l *Logger) Info(ctx ...string) PrintFunc {
-	if l.level >= LevelError {
+	if l.level >= LevelInfo {
 		return l.logWrite(InfoStr, ctx...)
 	}
 	return NoOpLogger("", "")
@@ -87,7 +97,7 @@ func (l *Logger) Info(ctx ...string) PrintFunc {
 //Pass Contexts along with actual debug messages
 //Verifies if Debug is allowed, if so adds Debug String
 func (l *Logger) Debug(ctx ...string) PrintFunc {
-	if l.level >= LevelError {
+	if l.level >= LevelDebug {
 		return l.logWrite(DebugStr, ctx...)
 	}
 	return NoOpLogger("", "")
@@ -97,9 +107,11 @@ func (l *Logger) Debug(ctx ...string) PrintFunc {
 //uses square braces [] to wrap contexts
 //uses | to split contexts
 //uses <> to wrap actual log message
+//Writes via Output (not Printf) with calldepth 2, so Lshortfile/Llongfile
+//report the caller of the returned PrintFunc, not this closure.
 func (l *Logger) logWrite(level string, ctx ...string) PrintFunc {
 	prefix := level + "[" + strings.Join(ctx, "|") + "]" // Add Logging Level and [headers]
 	return func(format string, args ...interface{}) {
-		l.log.Printf(prefix+"<"+format+">", args) // Add Log message within <>
+		l.log.Output(2, fmt.Sprintf(prefix+"<"+format+">", args...)) // Add Log message within <>
 	}
 }
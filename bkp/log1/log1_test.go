@@ -0,0 +1,53 @@
+package log
+
+import (
+	"bytes"
+	stdlog "log"
+	"strings"
+	"testing"
+)
+
+//TestLogWriteMultiArg - logWrite used to pass the variadic args slice as a
+//single argument to Printf, so every verb after the first misformatted.
+func TestLogWriteMultiArg(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "pfx", 0).Level(LevelDebug)
+
+	l.Error("ctx")("name=%s age=%d", "bob", 5)
+
+	got := buf.String()
+	if !strings.Contains(got, "name=bob age=5") {
+		t.Fatalf("multi-arg format not expanded, got %q", got)
+	}
+}
+
+//TestLogWriteSliceVerb - %v on a slice must render the slice, not the
+//address of the args slice that held it.
+func TestLogWriteSliceVerb(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "pfx", 0).Level(LevelDebug)
+
+	l.Info("ctx")("values=%v", []int{1, 2, 3})
+
+	got := buf.String()
+	if !strings.Contains(got, "values=[1 2 3]") {
+		t.Fatalf("slice verb not expanded, got %q", got)
+	}
+}
+
+//TestLogWriteWithFlags - exercises logWrite combined with Ldate|Lshortfile,
+//the stdlog flags most likely to interact badly with a misformatted Printf
+func TestLogWriteWithFlags(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "pfx", stdlog.Ldate|stdlog.Lshortfile).Level(LevelDebug)
+
+	l.Debug()("count=%d of %d", 2, 10)
+
+	got := buf.String()
+	if !strings.Contains(got, "count=2 of 10") {
+		t.Fatalf("format not expanded with Ldate|Lshortfile set, got %q", got)
+	}
+	if !strings.Contains(got, "log1_test.go") {
+		t.Fatalf("Lshortfile caller info missing, got %q", got)
+	}
+}
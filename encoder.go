@@ -0,0 +1,110 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+//Encoding - Names of the built-in Encoders, set via Cfg.Encoding
+const (
+	//EncodingText - Default encoding, the legacy level<msg> text form with
+	//Fields as key=val inside the <>; it has no [ctx] segment
+	EncodingText = "text"
+	//EncodingJSON - One JSON object per log line
+	EncodingJSON = "json"
+	//EncodingLogfmt - key=value pairs, one per log line
+	EncodingLogfmt = "logfmt"
+)
+
+//Encoder - Turns a level, message and Fields into the line written to the Sink
+type Encoder interface {
+	Encode(level, msg string, fields []Field) string
+}
+
+//encoderFor - Resolves Cfg.Encoding to an Encoder, defaulting to TextEncoder
+func encoderFor(encoding string) Encoder {
+	switch encoding {
+	case EncodingJSON:
+		return JSONEncoder{}
+	case EncodingLogfmt:
+		return LogfmtEncoder{}
+	default:
+		return TextEncoder{}
+	}
+}
+
+//TextEncoder - Preserves today's level<msg key=val ...> format
+type TextEncoder struct{}
+
+//Encode - Builds the legacy bracketed text line
+func (TextEncoder) Encode(level, msg string, fields []Field) string {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString("<")
+	b.WriteString(msg)
+	for _, f := range fields {
+		b.WriteString(" ")
+		b.WriteString(f.Key)
+		b.WriteString("=")
+		fmt.Fprintf(&b, "%v", f.Value)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+//JSONEncoder - Emits one JSON object per log line, for log aggregation pipelines
+type JSONEncoder struct{}
+
+//Encode - Builds a JSON line with "level", "msg" and the Field keys
+func (JSONEncoder) Encode(level, msg string, fields []Field) string {
+	m := make(map[string]interface{}, len(fields)+2)
+	m["level"] = level
+	m["msg"] = msg
+	for _, f := range fields {
+		m[f.Key] = jsonSafeValue(f.Value)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Sprintf(`{"level":%q,"msg":%q,"encodeError":%q}`, level, msg, err.Error())
+	}
+	return string(b)
+}
+
+//jsonSafeValue - encoding/json marshals an error with no exported fields
+//(e.g. errors.New's) to "{}", and a Stringer by its underlying value (a
+//time.Duration as integer nanoseconds). Render both as the text a reader
+//actually wants (the whole point of attaching the field).
+func jsonSafeValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case error:
+		return t.Error()
+	case fmt.Stringer:
+		return t.String()
+	default:
+		return v
+	}
+}
+
+//LogfmtEncoder - Emits key=value pairs, one per log line
+type LogfmtEncoder struct{}
+
+//Encode - Builds a logfmt line: level=.. msg=".." key=val ...
+func (LogfmtEncoder) Encode(level, msg string, fields []Field) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "level=%s msg=%q", level, msg)
+	for _, f := range fields {
+		fmt.Fprintf(&b, " %s=%s", f.Key, logfmtValue(f.Value))
+	}
+	return b.String()
+}
+
+//logfmtValue - Renders v the way %v would, quoting it when it contains a
+//space, "=" or `"`, any of which would otherwise break logfmt parsing
+func logfmtValue(v interface{}) string {
+	s := fmt.Sprintf("%v", v)
+	if strings.ContainsAny(s, ` ="`) {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
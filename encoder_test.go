@@ -0,0 +1,61 @@
+package log
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONEncoderEncode(t *testing.T) {
+	got := JSONEncoder{}.Encode(ErrStr, "boom", []Field{String("op", "write"), Int("n", 3)})
+
+	for _, want := range []string{`"level":"ER"`, `"msg":"boom"`, `"op":"write"`, `"n":3`} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("JSONEncoder.Encode() = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestLogfmtEncoderEncode(t *testing.T) {
+	got := LogfmtEncoder{}.Encode(InfoStr, "started", []Field{String("svc", "api")})
+
+	for _, want := range []string{`level=INF`, `msg="started"`, `svc=api`} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("LogfmtEncoder.Encode() = %q, missing %q", got, want)
+		}
+	}
+}
+
+//TestJSONEncoderErrorField - an error has no exported fields, so
+//json.Marshal would otherwise flatten it to "{}", dropping its message.
+func TestJSONEncoderErrorField(t *testing.T) {
+	got := JSONEncoder{}.Encode(ErrStr, "boom", []Field{Error(errors.New("disk full"))})
+
+	if !strings.Contains(got, `"error":"disk full"`) {
+		t.Fatalf("JSONEncoder.Encode() = %q, want it to contain the error message", got)
+	}
+}
+
+//TestLogfmtEncoderQuotesSpaces - a value containing a space or "=" must be
+//quoted, or a logfmt parser reads it as more than one key=value pair.
+func TestLogfmtEncoderQuotesSpaces(t *testing.T) {
+	got := LogfmtEncoder{}.Encode(ErrStr, "boom", []Field{Error(errors.New("disk full"))})
+
+	if !strings.Contains(got, `error="disk full"`) {
+		t.Fatalf("LogfmtEncoder.Encode() = %q, want the value quoted", got)
+	}
+}
+
+func TestEncoderFor(t *testing.T) {
+	cases := map[string]Encoder{
+		EncodingJSON:   JSONEncoder{},
+		EncodingLogfmt: LogfmtEncoder{},
+		EncodingText:   TextEncoder{},
+		"":             TextEncoder{},
+	}
+	for encoding, want := range cases {
+		if got := encoderFor(encoding); got != want {
+			t.Errorf("encoderFor(%q) = %T, want %T", encoding, got, want)
+		}
+	}
+}
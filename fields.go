@@ -0,0 +1,37 @@
+package log
+
+import "time"
+
+//Field - Typed key/value pair attached to a log line.
+//Build Fields with the constructors below (String, Int, Error, Duration, Any)
+//and pass them to With/Errorw/Infow/Debugw.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+//String - Field carrying a string value
+func String(key, val string) Field {
+	return Field{Key: key, Value: val}
+}
+
+//Int - Field carrying an int value
+func Int(key string, val int) Field {
+	return Field{Key: key, Value: val}
+}
+
+//Error - Field carrying an error value, keyed as "error"
+//Usage - log.Error(err)
+func Error(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+//Duration - Field carrying a time.Duration value
+func Duration(key string, val time.Duration) Field {
+	return Field{Key: key, Value: val}
+}
+
+//Any - Field carrying any value, use when no typed constructor fits
+func Any(key string, val interface{}) Field {
+	return Field{Key: key, Value: val}
+}
@@ -0,0 +1,157 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+//FileSink - A Sink backed by a file that rotates on size, keeping at most
+//MaxBackups old copies (optionally gzip-compressed). Age only prunes rotated
+//copies older than MaxAgeDays; the active file is never rotated on time.
+type FileSink struct {
+	path       string
+	maxSizeMB  int
+	maxAgeDays int
+	maxBackups int
+	gzip       bool
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+//NewFileSink - Opens (creating if needed) path for append and returns a
+//FileSink that rotates it once it exceeds maxSizeMB. maxAgeDays and
+//maxBackups bound how many rotated files are kept; gzip compresses them.
+//A zero value for maxSizeMB/maxAgeDays/maxBackups disables that bound.
+func NewFileSink(path string, maxSizeMB, maxAgeDays, maxBackups int, gzip bool) (*FileSink, error) {
+	f := &FileSink{
+		path:       path,
+		maxSizeMB:  maxSizeMB,
+		maxAgeDays: maxAgeDays,
+		maxBackups: maxBackups,
+		gzip:       gzip,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileSink) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("log: open %s: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("log: stat %s: %w", f.path, err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+//Write - Implements io.Writer, rotating the file first if p would push it
+//past maxSizeMB
+func (f *FileSink) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSizeMB > 0 && f.size+int64(len(p)) > int64(f.maxSizeMB)*1024*1024 {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+//rotate - Closes the current file, renames it with a timestamp suffix
+//(gzipping it if requested), reopens path fresh and prunes old backups
+func (f *FileSink) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("log: close %s: %w", f.path, err)
+	}
+
+	backup := fmt.Sprintf("%s.%s", f.path, time.Now().Format("20060102T150405.000000000"))
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("log: rotate %s: %w", f.path, err)
+	}
+	if f.gzip {
+		if err := gzipFile(backup); err != nil {
+			return err
+		}
+		backup += ".gz"
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+	return f.prune()
+}
+
+//prune - Removes rotated backups beyond maxBackups or older than maxAgeDays
+func (f *FileSink) prune() error {
+	if f.maxBackups <= 0 && f.maxAgeDays <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return fmt.Errorf("log: glob %s: %w", f.path, err)
+	}
+	sort.Strings(matches) // timestamp suffix sorts oldest-first
+
+	if f.maxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -f.maxAgeDays)
+		kept := matches[:0]
+		for _, m := range matches {
+			info, err := os.Stat(m)
+			if err == nil && info.ModTime().Before(cutoff) {
+				os.Remove(m)
+				continue
+			}
+			kept = append(kept, m)
+		}
+		matches = kept
+	}
+
+	if f.maxBackups > 0 && len(matches) > f.maxBackups {
+		for _, m := range matches[:len(matches)-f.maxBackups] {
+			os.Remove(m)
+		}
+	}
+	return nil
+}
+
+//gzipFile - Compresses path in place, replacing it with path+".gz"
+func gzipFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("log: open %s for gzip: %w", path, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(path + ".gz")
+	if err != nil {
+		return fmt.Errorf("log: create %s.gz: %w", path, err)
+	}
+	defer out.Close()
+
+	gw := gzip.NewWriter(out)
+	if _, err := io.Copy(gw, in); err != nil {
+		return fmt.Errorf("log: gzip %s: %w", path, err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("log: gzip %s: %w", path, err)
+	}
+	return os.Remove(path)
+}
@@ -1,9 +1,10 @@
 package log
 
 import (
+	"fmt"
 	stdlog "log"
-	"os"
 	"strings"
+	"sync/atomic"
 )
 
 //Log Levels and Strings
@@ -42,12 +43,52 @@ type Cfg struct {
 	Prefix string `yaml:"Prefix" valid:"ascii, required"`
 	// Log Flags
 	Flags int `yaml:"Flags" valid:"numeric,required"`
+	// Encoding selects the Encoder used by the fields-based *w methods
+	// (EncodingText/EncodingJSON/EncodingLogfmt). Defaults to EncodingText.
+	Encoding string `yaml:"Encoding" valid:"-"`
+	// Outputs routes each level to one or more Sinks. Leave empty to keep
+	// the default behaviour of writing everything to os.Stdout.
+	Outputs []OutputCfg `yaml:"Outputs"`
+}
+
+//Numeric level values backing Logger.lvl, ordered so that a higher
+//value enables more verbose logging. lvlOff disables all levels.
+const (
+	lvlError int32 = iota
+	lvlInfo
+	lvlDebug
+	lvlOff int32 = -1
+)
+
+//levelNum - Maps a LevelError/LevelInfo/LevelDebug string to its numeric
+//bucket, or lvlOff for anything else (disables that logger/output entirely)
+func levelNum(level string) int32 {
+	switch level {
+	case LevelDebug:
+		return lvlDebug
+	case LevelInfo:
+		return lvlInfo
+	case LevelError:
+		return lvlError
+	default:
+		return lvlOff
+	}
 }
 
 //Logger - Logger with levels; uses standard logger internally
+//lvl is a pointer so SubLogger/Named children share it with the root:
+//changing the root's Level propagates to every descendant.
+//logs holds one *stdlog.Logger per level bucket (lvlError/lvlInfo/lvlDebug),
+//so Cfg.Outputs can route each level to a different Sink.
 type Logger struct {
-	log                      *stdlog.Logger
-	errorFn, debugFn, infoFn LoggerFunc
+	logs      [3]*stdlog.Logger
+	lvl       *int32
+	encoder   Encoder
+	fields    []Field
+	subPrefix string
+	stickyCtx []string
+	async     *asyncSink
+	sampler   *sampler
 }
 
 //PrintFunc - Func type returned by Log Methods
@@ -63,18 +104,21 @@ var (
 	}
 )
 
-//New - Creates new Logger with requested prefix and flags
+//New - Creates new Logger with requested prefix and flags.
+//Writes to os.Stdout by default; set Cfg.Outputs to route levels to
+//FileSink/MultiSink/SyslogSink destinations instead.
 func New(c Cfg) *Logger {
-	l := stdlog.New(
-		os.Stdout, // By Default write to std out
-		c.Prefix+" ",
-		c.Flags)
+	writers := buildWriters(c)
 
-	//Set All Methods to NoOpLogger, by Default
-	logger := &Logger{log: l,
-		errorFn: NoOpFn,
-		debugFn: NoOpFn,
-		infoFn:  NoOpFn}
+	var logs [3]*stdlog.Logger
+	for i, w := range writers {
+		logs[i] = stdlog.New(w, c.Prefix+" ", c.Flags)
+	}
+
+	lvl := lvlOff
+	logger := &Logger{logs: logs,
+		lvl:     &lvl,
+		encoder: encoderFor(c.Encoding)}
 
 	//Set Level for logger
 	logger.Level(c.Level)
@@ -83,27 +127,27 @@ func New(c Cfg) *Logger {
 }
 
 //Level - Change Logger Level Supported Levels (Error/Info/Debug)
-//Can be called dynamically to change log level
+//Can be called dynamically to change log level.
+//Propagates to every SubLogger/Named descendant, since they share lvl.
 func (l *Logger) Level(level string) *Logger {
-	switch level {
-	case LevelDebug:
-		l.debugFn = l.logWrite
-		fallthrough //If Debug is requested, enable All Levels
-	case LevelInfo:
-		l.infoFn = l.logWrite
-		fallthrough //If Info is requested, enable Info and Error
-	case LevelError:
-		l.errorFn = l.logWrite
-	}
+	atomic.StoreInt32(l.lvl, levelNum(level))
 	return l
 }
 
+//enabled - Reports whether the logger's current level allows n
+func (l *Logger) enabled(n int32) bool {
+	return atomic.LoadInt32(l.lvl) >= n
+}
+
 //Error - Error logging.
 //Usage - log.Error(context info)(Log Message)
 //Pass Contexts along with actual error messages
 //Verifies if Error is allowed, if so adds  Error String
 func (l *Logger) Error(ctx ...string) PrintFunc {
-	return l.errorFn(ErrStr, ctx...)
+	if !l.enabled(lvlError) {
+		return NoOpFn("", "")
+	}
+	return l.logWrite(lvlError, ErrStr, ctx...)
 }
 
 //Info - Info logging.
@@ -111,7 +155,10 @@ func (l *Logger) Error(ctx ...string) PrintFunc {
 //Pass Contexts along with actual info messages
 //Verifies if Info is allowed, if so adds Info String
 func (l *Logger) Info(ctx ...string) PrintFunc {
-	return l.infoFn(InfoStr, ctx...)
+	if !l.enabled(lvlInfo) {
+		return NoOpFn("", "")
+	}
+	return l.logWrite(lvlInfo, InfoStr, ctx...)
 }
 
 //Debug - Debug logging.
@@ -119,17 +166,77 @@ func (l *Logger) Info(ctx ...string) PrintFunc {
 //Pass Contexts along with actual debug messages
 //Verifies if Debug is allowed, if so adds Debug String
 func (l *Logger) Debug(ctx ...string) PrintFunc {
-	return l.debugFn(DebugStr, ctx...)
+	if !l.enabled(lvlDebug) {
+		return NoOpFn("", "")
+	}
+	return l.logWrite(lvlDebug, DebugStr, ctx...)
+}
+
+//With - Returns a sub-logger that sticks the given Fields onto every
+//Errorw/Infow/Debugw call made on it, in addition to any Fields already
+//carried by l. The parent Logger is left untouched.
+func (l *Logger) With(fields ...Field) *Logger {
+	sub := *l
+	sub.fields = append(append([]Field{}, l.fields...), fields...)
+	return &sub
+}
+
+//Errorw - Structured Error logging.
+//Usage - log.Errorw("message", log.String("key", "value"))
+//Verifies if Error is allowed, if so encodes msg and fields via the Logger's Encoder
+func (l *Logger) Errorw(msg string, fields ...Field) {
+	if l.enabled(lvlError) {
+		l.writeFields(lvlError, ErrStr, msg, fields)
+	}
+}
+
+//Infow - Structured Info logging.
+//Usage - log.Infow("message", log.String("key", "value"))
+//Verifies if Info is allowed, if so encodes msg and fields via the Logger's Encoder
+func (l *Logger) Infow(msg string, fields ...Field) {
+	if l.enabled(lvlInfo) {
+		l.writeFields(lvlInfo, InfoStr, msg, fields)
+	}
+}
+
+//Debugw - Structured Debug logging.
+//Usage - log.Debugw("message", log.String("key", "value"))
+//Verifies if Debug is allowed, if so encodes msg and fields via the Logger's Encoder
+func (l *Logger) Debugw(msg string, fields ...Field) {
+	if l.enabled(lvlDebug) {
+		l.writeFields(lvlDebug, DebugStr, msg, fields)
+	}
+}
+
+//writeFields - Merges sticky Fields with call-site Fields and writes the
+//encoded line through the stdlog.Logger for level n
+func (l *Logger) writeFields(n int32, level, msg string, fields []Field) {
+	if l.subPrefix != "" {
+		msg = l.subPrefix + ": " + msg
+	}
+	if l.sampler != nil && !l.sampler.allow(hashKey(level, msg)) {
+		return
+	}
+	all := append(append([]Field{}, l.fields...), fields...)
+	l.output(n, l.encoder.Encode(level, msg, all))
 }
 
 //logWrite - function to write Log , Joins context and actual log.
 //uses square braces [] to wrap contexts
 //uses | to split contexts
 //uses <> to wrap actual log message
-func (l *Logger) logWrite(level string, ctx ...string) PrintFunc {
-	prefix := level + "[" + strings.Join(ctx, "|") + "]" // Add Logging Level and [headers]
+//Prepends subPrefix and any sticky context inherited from SubLogger/Named
+func (l *Logger) logWrite(n int32, level string, ctx ...string) PrintFunc {
+	if l.subPrefix != "" {
+		level = l.subPrefix + ":" + level
+	}
+	allCtx := append(append([]string{}, l.stickyCtx...), ctx...)
+	prefix := level + "[" + strings.Join(allCtx, "|") + "]" // Add Logging Level and [headers]
 	return func(format string, args ...interface{}) {
-		l.log.Printf(prefix+"<"+format+">", args...) // Add Log message within <>
+		if l.sampler != nil && !l.sampler.allow(hashKey(level, format)) {
+			return
+		}
+		l.output(n, fmt.Sprintf(prefix+"<"+format+">", args...)) // Add Log message within <>
 	}
 }
 
@@ -0,0 +1,17 @@
+package log
+
+//MultiSink - A Sink that fans every write out to several Sinks, e.g. to
+//send Error records to both stderr and a SyslogSink. Returns the first
+//error encountered, after still attempting every Sink.
+type MultiSink []Sink
+
+//Write - Implements io.Writer by writing p to every Sink in m
+func (m MultiSink) Write(p []byte) (int, error) {
+	var firstErr error
+	for _, s := range m {
+		if _, err := s.Write(p); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return len(p), firstErr
+}
@@ -0,0 +1,93 @@
+package log
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+//sampler - Per (level, message-template) rate limiter: inside every tick
+//window, the first N records pass through and then only every Mth one does
+type sampler struct {
+	tick       time.Duration
+	first      int
+	thereafter int
+
+	mu     sync.Mutex
+	counts map[uint64]*sampleCounter
+}
+
+type sampleCounter struct {
+	windowStart time.Time
+	n           int
+}
+
+//allow - Reports whether the record behind key should be emitted
+func (s *sampler) allow(key uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	c, ok := s.counts[key]
+	if !ok || now.Sub(c.windowStart) >= s.tick {
+		c = &sampleCounter{windowStart: now}
+		s.counts[key] = c
+	}
+	c.n++
+	if c.n <= s.first {
+		return true
+	}
+	if s.thereafter < 1 {
+		return false // drop every record after the first ones
+	}
+	return (c.n-s.first)%s.thereafter == 0
+}
+
+//hashKey - Hashes level+s (a format string or message) to the sampler's key
+func hashKey(level, s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(level))
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+//Sampled - Returns a sub-logger that rate-limits every Error/Info/Debug and
+//Errorw/Infow/Debugw call: inside each tick window, the first records are
+//always emitted and thereafter only every thereafter-th one is (none, when
+//thereafter < 1), per distinct (level, format/message) pair. Useful to keep
+//a hot, repeating error from overwhelming the sink. The parent Logger is
+//left unthrottled.
+func Sampled(l *Logger, tick time.Duration, first, thereafter int) *Logger {
+	sub := *l
+	sub.sampler = &sampler{
+		tick:       tick,
+		first:      first,
+		thereafter: thereafter,
+		counts:     make(map[uint64]*sampleCounter),
+	}
+	return &sub
+}
+
+//Every - Returns a PrintFunc that emits at Info level at most once per d,
+//silently dropping calls in between. Usage - tick := l.Every(time.Second);
+//call tick("...") as often as you like, it only writes once per d.
+func (l *Logger) Every(d time.Duration) PrintFunc {
+	pf := l.logWrite(lvlInfo, InfoStr)
+
+	var mu sync.Mutex
+	var last time.Time
+	return func(format string, args ...interface{}) {
+		mu.Lock()
+		now := time.Now()
+		due := now.Sub(last) >= d
+		if due {
+			last = now
+		}
+		mu.Unlock()
+
+		if !due || !l.enabled(lvlInfo) {
+			return
+		}
+		pf(format, args...)
+	}
+}
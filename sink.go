@@ -0,0 +1,91 @@
+package log
+
+import (
+	"io"
+	"io/ioutil"
+	stdlog "log"
+	"os"
+)
+
+//Sink - Destination a Logger can write formatted lines to. Any io.Writer
+//qualifies; FileSink, MultiSink and SyslogSink are the built-in ones.
+type Sink interface {
+	io.Writer
+}
+
+//OutputCfg - One entry of Cfg.Outputs: a destination plus the minimum
+//Level (Error/Info/Debug) of record it should receive. An output configured
+//with Level: Error only ever sees Error records; Level: Debug sees all three.
+type OutputCfg struct {
+	// Path to write to. "stdout"/"stderr" (or "") select those streams,
+	// anything else is rotated via FileSink. Ignored when Syslog is true.
+	Path string `yaml:"Path"`
+	// Level - minimum severity this output should receive
+	Level string `yaml:"Level" valid:"Level,required"`
+	// Syslog - if true, write via SyslogSink instead of Path
+	Syslog bool `yaml:"Syslog"`
+	// MaxSizeMB - rotate the file once it exceeds this size, in MB
+	MaxSizeMB int `yaml:"MaxSizeMB"`
+	// MaxAgeDays - delete rotated files older than this many days
+	MaxAgeDays int `yaml:"MaxAgeDays"`
+	// MaxBackups - keep at most this many rotated files
+	MaxBackups int `yaml:"MaxBackups"`
+	// Gzip - compress rotated files
+	Gzip bool `yaml:"Gzip"`
+}
+
+//sink - Builds the Sink described by this OutputCfg entry
+func (o OutputCfg) sink() (Sink, error) {
+	if o.Syslog {
+		return NewSyslogSink()
+	}
+	switch o.Path {
+	case "stdout", "":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	default:
+		return NewFileSink(o.Path, o.MaxSizeMB, o.MaxAgeDays, o.MaxBackups, o.Gzip)
+	}
+}
+
+//buildWriters - Resolves Cfg.Outputs into one Sink per level bucket
+//(lvlError/lvlInfo/lvlDebug), fanning out via MultiSink when several
+//outputs target the same bucket. With no Outputs configured, New falls
+//back to the legacy behaviour of writing everything to os.Stdout.
+func buildWriters(c Cfg) [3]io.Writer {
+	var out [3]io.Writer
+	if len(c.Outputs) == 0 {
+		for i := range out {
+			out[i] = os.Stdout
+		}
+		return out
+	}
+
+	var sinks [3][]Sink
+	for _, o := range c.Outputs {
+		s, err := o.sink()
+		if err != nil {
+			// Misconfigured output: drop it rather than fail New, and
+			// surface the problem where an operator will see it.
+			stdlog.Printf("log: skipping output %+v: %v", o, err)
+			continue
+		}
+		n := levelNum(o.Level)
+		for i := int32(0); i <= n && int(i) < len(sinks); i++ {
+			sinks[i] = append(sinks[i], s)
+		}
+	}
+
+	for i := range sinks {
+		switch len(sinks[i]) {
+		case 0:
+			out[i] = ioutil.Discard
+		case 1:
+			out[i] = sinks[i][0]
+		default:
+			out[i] = MultiSink(sinks[i])
+		}
+	}
+	return out
+}
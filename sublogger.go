@@ -0,0 +1,25 @@
+package log
+
+//SubLogger - Returns a child Logger that shares the underlying stdlog.Logger,
+//Level and Encoder with l, but tags every line it emits with prefix and the
+//given sticky ctx (merged with any ctx/fields l itself already carries).
+//The sticky ctx appears only in Error/Info/Debug lines; Errorw/Infow/Debugw
+//lines carry the prefix and Fields but not the sticky ctx.
+//Usage - db := root.SubLogger("db"); db.Error("conn")("timeout: %v", err)
+func (l *Logger) SubLogger(prefix string, ctx ...string) *Logger {
+	child := *l
+	if l.subPrefix != "" {
+		child.subPrefix = l.subPrefix + "." + prefix
+	} else {
+		child.subPrefix = prefix
+	}
+	child.stickyCtx = append(append([]string{}, l.stickyCtx...), ctx...)
+	child.fields = append([]Field{}, l.fields...)
+	return &child
+}
+
+//Named - Convenience over SubLogger that composes names with "." so callers
+//can scope logs per subsystem, e.g. root.Named("db").Named("pool").
+func (l *Logger) Named(name string) *Logger {
+	return l.SubLogger(name)
+}
@@ -0,0 +1,31 @@
+package log
+
+import "testing"
+
+//TestLevelPropagatesToChildren - Level changes on a root Logger must be
+//visible to SubLogger/Named descendants, since they share the root's lvl.
+func TestLevelPropagatesToChildren(t *testing.T) {
+	root := New(Cfg{Level: LevelError, Prefix: "t", Flags: 0})
+	child := root.SubLogger("db").Named("pool")
+
+	if child.enabled(lvlDebug) {
+		t.Fatalf("child should not be Debug-enabled before root.Level(LevelDebug)")
+	}
+
+	root.Level(LevelDebug)
+
+	if !child.enabled(lvlDebug) {
+		t.Fatalf("child should be Debug-enabled after root.Level(LevelDebug)")
+	}
+}
+
+//TestSubLoggerComposesPrefix - nesting SubLogger/Named must compose the
+//prefix, not overwrite it.
+func TestSubLoggerComposesPrefix(t *testing.T) {
+	root := New(Cfg{Level: LevelDebug, Prefix: "t", Flags: 0})
+	child := root.SubLogger("a").SubLogger("b")
+
+	if want := "a.b"; child.subPrefix != want {
+		t.Fatalf("child.subPrefix = %q, want %q", child.subPrefix, want)
+	}
+}
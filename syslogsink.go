@@ -0,0 +1,30 @@
+//go:build !windows
+// +build !windows
+
+package log
+
+import "log/syslog"
+
+//SyslogSink - A Sink that forwards writes to the local syslog daemon
+type SyslogSink struct {
+	w *syslog.Writer
+}
+
+//NewSyslogSink - Dials the local syslog daemon at LOG_INFO/LOG_DAEMON.
+//Every line is sent at LOG_INFO severity, whatever its level; the level
+//is already embedded in the line by the Logger.
+func NewSyslogSink() (*SyslogSink, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "")
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogSink{w: w}, nil
+}
+
+//Write - Implements io.Writer by forwarding p to syslog
+func (s *SyslogSink) Write(p []byte) (int, error) {
+	if err := s.w.Info(string(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,20 @@
+//go:build windows
+// +build windows
+
+package log
+
+import "errors"
+
+//SyslogSink - Stub for windows, which has no local syslog daemon
+type SyslogSink struct{}
+
+//NewSyslogSink - Always fails on windows; there is no local syslog daemon
+//to dial. Configure a FileSink or stdout/stderr output there instead.
+func NewSyslogSink() (*SyslogSink, error) {
+	return nil, errors.New("log: syslog unsupported on windows")
+}
+
+//Write - Implements io.Writer; never called since NewSyslogSink always errors
+func (s *SyslogSink) Write(p []byte) (int, error) {
+	return 0, errors.New("log: syslog unsupported on windows")
+}